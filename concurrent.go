@@ -0,0 +1,276 @@
+package skiplist
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConcurrentSkipList 是 ZSet（score/value 有序集合）的并发安全封装。
+// 写操作（Insert/Delete）通过互斥锁串行化，并采用写时复制（copy-on-write）
+// 的方式构建新版本的底层跳跃表，再通过 atomic.Pointer 原子发布，因此读操作
+// （GetRank/FirstInRange/LastInRange 等）无需加锁即可并发执行，
+// 不会与写操作互相阻塞。
+type ConcurrentSkipList struct {
+	cur atomic.Pointer[SkipList[zsetKey, string]]
+	mu  sync.Mutex // 仅用于串行化写操作
+
+	versions  uint64 // 已发布的版本数，原子递增
+	reads     uint64 // 读快照次数，原子递增
+	readNanos int64  // 读快照累计耗时（纳秒），原子递增
+}
+
+// Stats 记录 ConcurrentSkipList 的运行时统计信息。
+type Stats struct {
+	Versions    uint64        // 自创建以来发布的版本数量
+	Reads       uint64        // 快照读取次数
+	AvgReadHold time.Duration // 平均每次获取快照的耗时
+}
+
+// NewConcurrent 创建一个空的 ConcurrentSkipList，opts 的含义与 New 相同。
+func NewConcurrent(opts ...Option) *ConcurrentSkipList {
+	c := &ConcurrentSkipList{}
+	c.cur.Store(New[zsetKey, string](compareZSetKey, opts...))
+	c.versions = 1
+	return c
+}
+
+// Stats 返回当前的统计信息快照。
+func (c *ConcurrentSkipList) Stats() Stats {
+	reads := atomic.LoadUint64(&c.reads)
+	var avg time.Duration
+	if reads > 0 {
+		avg = time.Duration(atomic.LoadInt64(&c.readNanos) / int64(reads))
+	}
+	return Stats{
+		Versions:    atomic.LoadUint64(&c.versions),
+		Reads:       reads,
+		AvgReadHold: avg,
+	}
+}
+
+// snapshot 无锁地获取当前版本，并记录读快照的耗时，供 Stats() 上报。
+func (c *ConcurrentSkipList) snapshot() *SkipList[zsetKey, string] {
+	start := time.Now()
+	sl := c.cur.Load()
+	atomic.AddUint64(&c.reads, 1)
+	atomic.AddInt64(&c.readNanos, int64(time.Since(start)))
+	return sl
+}
+
+// publish 原子地发布一个新版本，并更新版本计数。
+func (c *ConcurrentSkipList) publish(sl *SkipList[zsetKey, string]) {
+	c.cur.Store(sl)
+	atomic.AddUint64(&c.versions, 1)
+}
+
+// Insert 插入元素。内部先用 cloneList 复制整张表（而不只是受影响的节点，
+// 因此单次写操作是 O(n)），在副本上插入后发布新版本，不会阻塞正在进行的读操作。
+func (c *ConcurrentSkipList) Insert(score float64, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publish(cowInsert(c.cur.Load(), zsetKey{Score: score, Value: value}, value))
+}
+
+// Delete 删除匹配的元素<score, value>，语义与 ZSet.Delete 一致。
+func (c *ConcurrentSkipList) Delete(score float64, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cur := c.cur.Load()
+	next, err := cowDelete(cur, zsetKey{Score: score, Value: value})
+	if err != nil {
+		return err
+	}
+	c.publish(next)
+	return nil
+}
+
+// GetRank 返回目标元素在有序集中的 rank，读取不阻塞写操作。
+func (c *ConcurrentSkipList) GetRank(score float64, value string) (uint64, error) {
+	return c.snapshot().GetRank(zsetKey{Score: score, Value: value})
+}
+
+// GetValueByRank 根据给定的 rank 查找元素，读取不阻塞写操作。
+func (c *ConcurrentSkipList) GetValueByRank(rank uint64) (string, error) {
+	return c.snapshot().GetValueByRank(rank)
+}
+
+// FirstInRange 找到跳跃表中第一个 Score 落在给定范围内的元素，读取不阻塞写操作。
+func (c *ConcurrentSkipList) FirstInRange(r Range[float64]) (*ZSetNode, error) {
+	sl := c.snapshot()
+	node, err := firstInScoreRange(sl, r)
+	if err != nil {
+		return nil, err
+	}
+	return zsetNode(node), nil
+}
+
+// LastInRange 找到跳跃表中最后一个 Score 落在给定范围内的元素，读取不阻塞写操作。
+func (c *ConcurrentSkipList) LastInRange(r Range[float64]) (*ZSetNode, error) {
+	sl := c.snapshot()
+	node, err := lastInScoreRange(sl, r)
+	if err != nil {
+		return nil, err
+	}
+	return zsetNode(node), nil
+}
+
+// Len 返回当前版本跳跃表中的元素数量。
+func (c *ConcurrentSkipList) Len() uint64 {
+	return c.snapshot().Len
+}
+
+// firstInScoreRange 与 ZSet.FirstInRange 的查找逻辑一致，范围仅按 Score 比较。
+func firstInScoreRange(sl *SkipList[zsetKey, string], r Range[float64]) (*SkipNode[zsetKey, string], error) {
+	tail := sl.Tail
+	if tail == nil || tail.Key.Score < r.Min || r.Min > r.Max {
+		return nil, ErrNotFound
+	}
+
+	x := sl.Header
+	for i := sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && x.Level[i].Forward.Key.Score < r.Min {
+			x = x.Level[i].Forward
+		}
+	}
+
+	x = x.Level[0].Forward
+	if x == nil || x.Key.Score > r.Max {
+		return nil, ErrNotFound
+	}
+	return x, nil
+}
+
+// lastInScoreRange 与 ZSet.LastInRange 的查找逻辑一致，范围仅按 Score 比较。
+func lastInScoreRange(sl *SkipList[zsetKey, string], r Range[float64]) (*SkipNode[zsetKey, string], error) {
+	head := sl.Header.Level[0].Forward
+	if head == nil || head.Key.Score > r.Max || r.Min > r.Max {
+		return nil, ErrNotFound
+	}
+
+	x := sl.Header
+	for i := sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && x.Level[i].Forward.Key.Score <= r.Max {
+			x = x.Level[i].Forward
+		}
+	}
+
+	if x == sl.Header || x.Key.Score < r.Min {
+		return nil, ErrNotFound
+	}
+	return x, nil
+}
+
+// SnapshotIterator 在创建时钉住（pin）一个版本的跳跃表，之后的遍历始终
+// 基于该版本，不受后续写操作影响，从而保证遍历过程中的一致性视图。
+type SnapshotIterator struct {
+	sl   *SkipList[zsetKey, string]
+	node *SkipNode[zsetKey, string]
+}
+
+// Iterator 返回一个钉住当前版本的迭代器，从第一个元素开始遍历。
+func (c *ConcurrentSkipList) Iterator() *SnapshotIterator {
+	sl := c.snapshot()
+	return &SnapshotIterator{sl: sl, node: sl.Header.Level[0].Forward}
+}
+
+// Valid 报告迭代器当前是否指向一个有效节点。
+func (it *SnapshotIterator) Valid() bool {
+	return it.node != nil
+}
+
+// Next 将迭代器移动到下一个节点。
+func (it *SnapshotIterator) Next() {
+	if it.node != nil {
+		it.node = it.node.Level[0].Forward
+	}
+}
+
+// Prev 将迭代器移动到上一个节点。
+func (it *SnapshotIterator) Prev() {
+	if it.node != nil {
+		it.node = it.node.Backward
+	}
+}
+
+// Value 返回迭代器当前指向的节点的值。
+func (it *SnapshotIterator) Value() string {
+	return it.node.Value
+}
+
+// Score 返回迭代器当前指向的节点的分值。
+func (it *SnapshotIterator) Score() float64 {
+	return it.node.Key.Score
+}
+
+// cloneList 深拷贝 sl：返回一个不与 sl 共享任何节点的独立副本，每个节点的
+// 层数、各层 Forward、指向实际节点的 Span 以及 Backward 与原表一致；Forward
+// 为 nil 的链接上的 Span 不复制，在副本中为 0。
+//
+// 早期实现尝试只克隆写操作路径上touch 到的节点（多层查找经过的 update[i]
+// 以及编辑点相邻的后继），企图把单次写操作维持在 O(log n)。但这组节点并不是
+// "与编辑无关就可以安全共享" 的：(1) Backward 是一条独立维护的 0 层反向链，
+// 编辑点之后的每个节点的 Backward 严格来说都需要换成指向新版本的前驱，否则
+// 从表尾回溯会绕开编辑点；(2) 多层查找为了效率会在较高层直接跳过一些节点，
+// 这些被跳过的节点如果因为 (1) 被其它位置克隆，它们在更高层的前驱若没有同步
+// 更新，就会在后续查找里绕回旧版本、形成与当前 0 层链不一致的"孤儿"节点。
+// 要同时保证这两点而不付出整表重建的代价，需要对每一层都维护完整的前驱链，
+// 失去了分层查找本来的意义。既然 Backward 的正确性已经决定了写操作是
+// O(n)，这里干脆整表重建：复用已经过验证的 SkipList.Insert/Delete 写入克隆，
+// 不再尝试局部共享节点。
+func cloneList(sl *SkipList[zsetKey, string]) *SkipList[zsetKey, string] {
+	newSL := &SkipList[zsetKey, string]{
+		Header:      createNode[zsetKey, string](len(sl.Header.Level), sl.Header.Key, sl.Header.Value),
+		Level:       sl.Level,
+		compare:     sl.compare,
+		maxLevel:    sl.maxLevel,
+		probability: sl.probability,
+		rnd:         sl.rnd,
+	}
+
+	update := make([]*SkipNode[zsetKey, string], sl.maxLevel)
+	for i := range update {
+		update[i] = newSL.Header
+	}
+	span := make([]uint64, sl.maxLevel)
+
+	var tail *SkipNode[zsetKey, string]
+	for n := sl.Header.Level[0].Forward; n != nil; n = n.Level[0].Forward {
+		level := len(n.Level)
+		nc := createNode(level, n.Key, n.Value)
+		for i := range span {
+			span[i]++
+		}
+		for i := 0; i < level; i++ {
+			update[i].Level[i].Span = span[i]
+			update[i].Level[i].Forward = nc
+			update[i] = nc
+			span[i] = 0
+		}
+		nc.Backward = tail
+		tail = nc
+		newSL.Len++
+	}
+	newSL.Tail = tail
+
+	return newSL
+}
+
+// cowInsert 以写时复制的方式在 sl 上插入 <key, value>，返回插入后的新版本，
+// sl 本身保持不变，可以继续被其它 goroutine 安全读取。
+func cowInsert(sl *SkipList[zsetKey, string], key zsetKey, value string) *SkipList[zsetKey, string] {
+	next := cloneList(sl)
+	next.Insert(key, value)
+	return next
+}
+
+// cowDelete 以写时复制的方式在 sl 上删除 key，返回删除后的新版本。
+// 若元素不存在，返回 ErrNotFound，且不产生新版本。
+func cowDelete(sl *SkipList[zsetKey, string], key zsetKey) (*SkipList[zsetKey, string], error) {
+	next := cloneList(sl)
+	if err := next.Delete(key); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,110 @@
+package skiplist
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentSkipList(t *testing.T) {
+	c := NewConcurrent()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Insert(float64(i), "v")
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, uint64(100), c.Len())
+
+	rank, err := c.GetRank(50, "v")
+	require.NoError(t, err)
+	require.Equal(t, uint64(51), rank)
+
+	err = c.Delete(50, "v")
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), c.Len())
+
+	_, err = c.GetRank(50, "v")
+	require.Equal(t, ErrNotFound, err)
+
+	stats := c.Stats()
+	require.True(t, stats.Versions >= 101)
+	require.True(t, stats.Reads >= 2)
+}
+
+// collectForward 消耗 it，返回沿 Next() 遍历到的全部 value。
+func collectForward(it *SnapshotIterator) []string {
+	var values []string
+	for it.Valid() {
+		values = append(values, it.Value())
+		it.Next()
+	}
+	return values
+}
+
+func TestConcurrentSkipList_IteratorPrev(t *testing.T) {
+	c := NewConcurrent()
+	c.Insert(1, "a")
+	c.Insert(2, "b")
+	c.Insert(4, "d")
+
+	// 在 b、d 之间插入 c：新版本中 d 的 Backward 必须指向 c，
+	// 否则从表尾沿 Backward 回溯会跳过 c。
+	c.Insert(3, "c")
+
+	forward := collectForward(c.Iterator())
+	require.Equal(t, []string{"a", "b", "c", "d"}, forward)
+
+	it := c.Iterator()
+	for i := 0; i < len(forward)-1; i++ {
+		it.Next()
+	}
+	var backward []string
+	for it.Valid() {
+		backward = append(backward, it.Value())
+		it.Prev()
+	}
+	require.Equal(t, []string{"d", "c", "b", "a"}, backward)
+
+	// 删除中间节点 b 后重复验证，确保 cowDelete 生成的新版本中 Backward 链同样正确。
+	require.NoError(t, c.Delete(2, "b"))
+
+	forward = collectForward(c.Iterator())
+	require.Equal(t, []string{"a", "c", "d"}, forward)
+
+	it = c.Iterator()
+	for i := 0; i < len(forward)-1; i++ {
+		it.Next()
+	}
+	backward = nil
+	for it.Valid() {
+		backward = append(backward, it.Value())
+		it.Prev()
+	}
+	require.Equal(t, []string{"d", "c", "a"}, backward)
+}
+
+func TestConcurrentSkipList_IteratorPinsVersion(t *testing.T) {
+	c := NewConcurrent()
+	c.Insert(1, "a")
+	c.Insert(2, "b")
+
+	it := c.Iterator()
+
+	// 迭代器创建之后的写操作不应影响已经钉住的版本。
+	c.Insert(3, "c")
+	require.Equal(t, uint64(3), c.Len())
+
+	var values []string
+	for it.Valid() {
+		values = append(values, it.Value())
+		it.Next()
+	}
+	require.Equal(t, []string{"a", "b"}, values)
+}
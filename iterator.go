@@ -0,0 +1,151 @@
+package skiplist
+
+// Iterator 遍历 ZSet 中落在某个范围内的元素。它在创建时定位到范围内的第一
+// （或最后一个，取决于 reverse）个节点，之后 Next/Prev 只沿着该节点的
+// Level[0].Forward/Backward 指针移动，不会重新从 Header 按层查找，因此遍历
+// 期间即使并发发生了写操作，已经遍历到的节点依然保持有效（SkipList 不是
+// 并发安全的，如果需要与并发写操作共存，请改用 ConcurrentSkipList.Iterator）。
+type Iterator struct {
+	node    *SkipNode[zsetKey, string]
+	rank    uint64
+	reverse bool
+
+	// scoreRange 仅在通过 ScanByScore 构造时设置，Next/Prev 每次移动后都会
+	// 检查当前节点的 score 是否仍落在该范围内，越界则让迭代器失效。
+	scoreRange *Range[float64]
+
+	// hasRankRange、rankMin、rankMax 仅在通过 ScanByRank 构造时设置，
+	// 语义同上，只是检查对象换成了 rank。
+	hasRankRange bool
+	rankMin      uint64
+	rankMax      uint64
+}
+
+// ScanByScore 返回一个定位到 score 范围 r 内第一个（reverse=false）或最后一个
+// （reverse=true）元素的迭代器，对应 Redis 的 ZRANGEBYSCORE / ZREVRANGEBYSCORE。
+func (z *ZSet) ScanByScore(r Range[float64], reverse bool) *Iterator {
+	var node *SkipNode[zsetKey, string]
+	var err error
+	if reverse {
+		node, err = lastInScoreRange(z.sl, r)
+	} else {
+		node, err = firstInScoreRange(z.sl, r)
+	}
+	if err != nil {
+		return &Iterator{reverse: reverse, scoreRange: &r}
+	}
+
+	rank, _ := z.sl.GetRank(node.Key)
+	return &Iterator{node: node, rank: rank, reverse: reverse, scoreRange: &r}
+}
+
+// ScanByRank 返回一个定位到 [start, end] 排名范围内第一个（reverse=false）或
+// 最后一个（reverse=true）元素的迭代器，对应 Redis 的 ZRANGE / ZREVRANGE。
+// rank 与 SkipList.GetValueByRank 一致，从 1 开始。
+func (z *ZSet) ScanByRank(start, end uint64, reverse bool) *Iterator {
+	if start == 0 || start > end {
+		return &Iterator{reverse: reverse, hasRankRange: true, rankMin: start, rankMax: end}
+	}
+
+	rank := start
+	if reverse {
+		rank = end
+		if rank > z.sl.Len {
+			rank = z.sl.Len
+		}
+		if rank < start {
+			return &Iterator{reverse: reverse, hasRankRange: true, rankMin: start, rankMax: end}
+		}
+	}
+	node, err := nodeAtRank(z.sl, rank)
+	if err != nil {
+		return &Iterator{reverse: reverse, hasRankRange: true, rankMin: start, rankMax: end}
+	}
+
+	return &Iterator{node: node, rank: rank, reverse: reverse, hasRankRange: true, rankMin: start, rankMax: end}
+}
+
+// Valid 报告迭代器当前是否指向一个有效节点。
+func (it *Iterator) Valid() bool {
+	return it.node != nil
+}
+
+// Next 沿着本次扫描的方向移动到下一个元素，一旦移出构造时给定的范围，
+// 迭代器即失效（Valid 返回 false）。
+func (it *Iterator) Next() {
+	if it.node == nil {
+		return
+	}
+	if it.reverse {
+		it.node = it.node.Backward
+		it.rank--
+	} else {
+		it.node = it.node.Level[0].Forward
+		it.rank++
+	}
+	it.checkBounds()
+}
+
+// Prev 撤销一次 Next，移动到本次扫描方向上的上一个元素，同样会做越界检查。
+func (it *Iterator) Prev() {
+	if it.node == nil {
+		return
+	}
+	if it.reverse {
+		it.node = it.node.Level[0].Forward
+		it.rank++
+	} else {
+		it.node = it.node.Backward
+		it.rank--
+	}
+	it.checkBounds()
+}
+
+// checkBounds 检查当前节点是否仍落在构造时给定的 score/rank 范围内，
+// 一旦越界就让迭代器失效，避免 Next/Prev 无界地走出 Scan 的范围。
+func (it *Iterator) checkBounds() {
+	if it.node == nil {
+		return
+	}
+	if it.scoreRange != nil {
+		s := it.node.Key.Score
+		if s < it.scoreRange.Min || s > it.scoreRange.Max {
+			it.node = nil
+			return
+		}
+	}
+	if it.hasRankRange && (it.rank < it.rankMin || it.rank > it.rankMax) {
+		it.node = nil
+	}
+}
+
+// Value 返回迭代器当前指向元素的 value。
+func (it *Iterator) Value() string {
+	return it.node.Value
+}
+
+// Score 返回迭代器当前指向元素的 score。
+func (it *Iterator) Score() float64 {
+	return it.node.Key.Score
+}
+
+// Rank 返回迭代器当前指向元素的 rank。
+func (it *Iterator) Rank() uint64 {
+	return it.rank
+}
+
+// nodeAtRank 与 SkipList.GetValueByRank 的查找逻辑一致，但返回节点本身而不是其 Value。
+func nodeAtRank(sl *SkipList[zsetKey, string], rank uint64) (*SkipNode[zsetKey, string], error) {
+	x := sl.Header
+	traversed := uint64(0)
+	for i := sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && (traversed+x.Level[i].Span) <= rank {
+			traversed += x.Level[i].Span
+			x = x.Level[i].Forward
+		}
+		if traversed == rank {
+			return x, nil
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,54 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZSet_ScanByScore(t *testing.T) {
+	z := NewZSet()
+	z.Insert(1, "a")
+	z.Insert(2, "b")
+	z.Insert(3, "c")
+	z.Insert(4, "d")
+
+	it := z.ScanByScore(Range[float64]{Min: 2, Max: 3}, false)
+	var values []string
+	for it.Valid() {
+		values = append(values, it.Value())
+		it.Next()
+	}
+	require.Equal(t, []string{"b", "c"}, values)
+
+	it = z.ScanByScore(Range[float64]{Min: 2, Max: 3}, true)
+	values = nil
+	for it.Valid() {
+		values = append(values, it.Value())
+		it.Next()
+	}
+	require.Equal(t, []string{"c", "b"}, values)
+}
+
+func TestZSet_ScanByRank(t *testing.T) {
+	z := NewZSet()
+	z.Insert(1, "a")
+	z.Insert(2, "b")
+	z.Insert(3, "c")
+
+	it := z.ScanByRank(1, 2, false)
+	require.True(t, it.Valid())
+	require.Equal(t, uint64(1), it.Rank())
+	require.Equal(t, "a", it.Value())
+	it.Next()
+	require.Equal(t, "b", it.Value())
+	it.Next()
+	require.False(t, it.Valid())
+
+	it = z.ScanByRank(1, 3, true)
+	require.Equal(t, "c", it.Value())
+	it.Next()
+	require.Equal(t, "b", it.Value())
+	it.Prev()
+	require.Equal(t, "c", it.Value())
+}
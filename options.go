@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+	mrand "math/rand"
+	"time"
+)
+
+type options struct {
+	probability float64
+	maxLevel    int
+	source      mrand.Source
+}
+
+func defaultOptions() options {
+	return options{
+		probability: DefaultProbability,
+		maxLevel:    DefaultMaxLevel,
+		source:      mrand.NewSource(cryptoSeed()),
+	}
+}
+
+// Option 用于配置 New 创建的 SkipList。
+type Option func(*options)
+
+// WithProbability 设置新节点晋升到更高一层的概率 p（0 < p < 1）。
+// p 越小，层数增长越慢，空间开销越低；p 越大，查询越快，空间开销越高。
+func WithProbability(p float64) Option {
+	return func(o *options) {
+		if p > 0 && p < 1 {
+			o.probability = p
+		}
+	}
+}
+
+// WithMaxLevel 设置跳跃表允许的最大层数。
+func WithMaxLevel(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxLevel = n
+		}
+	}
+}
+
+// WithRandSource 指定 randomLevel 使用的随机数源，便于测试中复现确定性的层数分布。
+func WithRandSource(source mrand.Source) Option {
+	return func(o *options) {
+		if source != nil {
+			o.source = source
+		}
+	}
+}
+
+// OptimalMaxLevel 按照期望存储 expectedN 个元素、晋升概率为 p 计算出
+// 使查询复杂度维持在 O(log n) 所需的最小层数，即 ceil(log_{1/p}(expectedN))。
+func OptimalMaxLevel(expectedN uint64, p float64) int {
+	if expectedN <= 1 || p <= 0 || p >= 1 {
+		return 1
+	}
+	level := int(math.Ceil(math.Log(float64(expectedN)) / math.Log(1/p)))
+	if level < 1 {
+		return 1
+	}
+	return level
+}
+
+// cryptoSeed 从 crypto/rand 读取一个种子，读取失败时退化为基于当前时间的种子。
+func cryptoSeed() int64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.BigEndian.Uint64(buf[:]))
+}
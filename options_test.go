@@ -0,0 +1,28 @@
+package skiplist
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_WithProbabilityAndMaxLevel(t *testing.T) {
+	sl := New[int, string](compareInt, WithProbability(0.5), WithMaxLevel(8), WithRandSource(rand.NewSource(1)))
+
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, "v")
+	}
+
+	require.LessOrEqual(t, sl.Level, 8)
+	require.Equal(t, uint64(100), sl.Len)
+}
+
+func TestOptimalMaxLevel(t *testing.T) {
+	require.Equal(t, 1, OptimalMaxLevel(0, 0.25))
+	require.Equal(t, 1, OptimalMaxLevel(1, 0.25))
+
+	level := OptimalMaxLevel(1_000_000, 0.25)
+	require.Greater(t, level, 0)
+	require.LessOrEqual(t, level, DefaultMaxLevel)
+}
@@ -0,0 +1,240 @@
+// Package persist 在 skiplist.ZSet 之上叠加了类似 LevelDB memtable+WAL 的
+// 持久化能力：每次写操作先追加到预写日志（WAL），再应用到内存中的 ZSet；
+// 调用 Checkpoint 时内存表被刷成不可变的 segment 文件（后台 compactor 只清理
+// 旧 segment，不会自动刷盘），Open 时通过重放 WAL 与加载最新 segment 恢复内存状态。
+package persist
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/treeforest/skiplist"
+)
+
+// DB 是一个带持久化的有序集合，写操作与 skiplist.ZSet 对应方法的语义一致，
+// 额外返回 WAL 写入错误；DB 不提供读取方法。
+type DB struct {
+	mu  sync.Mutex
+	dir string
+	mem *skiplist.ZSet
+	wal *wal
+
+	segs []uint64 // 已持久化的 segment 序列号，升序排列
+
+	compactInterval time.Duration
+	closeC          chan struct{}
+	wg              sync.WaitGroup
+}
+
+// Open 打开（或创建）dir 下的数据库：加载最新的 segment 文件，
+// 再重放其序列号之后的 WAL 记录，重建出内存中的 ZSet。
+func Open(dir string) (*DB, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	segs, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := skiplist.NewZSet()
+	var checkpointSeq uint64
+	if len(segs) > 0 {
+		newest := segs[len(segs)-1]
+		data, unmap, err := mmapSegment(segmentPath(dir, newest))
+		if err != nil {
+			return nil, err
+		}
+		entries, _, err := parseSegment(data)
+		if err != nil {
+			unmap()
+			return nil, err
+		}
+		for _, e := range entries {
+			mem.Insert(e.score, e.value)
+		}
+		if err := unmap(); err != nil {
+			return nil, err
+		}
+		checkpointSeq = newest
+	}
+
+	w, err := openWAL(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.replay(checkpointSeq, func(rec record) error {
+		applyRecord(mem, rec)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if w.seq < checkpointSeq {
+		w.seq = checkpointSeq
+	}
+
+	db := &DB{
+		dir:             dir,
+		mem:             mem,
+		wal:             w,
+		segs:            segs,
+		compactInterval: time.Minute,
+		closeC:          make(chan struct{}),
+	}
+	db.wg.Add(1)
+	go db.compactLoop()
+
+	return db, nil
+}
+
+// applyRecord 将一条已经落盘的 WAL 记录应用到内存表，Open 重放与正常写入路径共用。
+func applyRecord(mem *skiplist.ZSet, rec record) {
+	switch rec.op {
+	case opInsert:
+		if score, value, ok := decodeInsertPayload(rec.payload); ok {
+			mem.Insert(score, value)
+		}
+	case opDelete:
+		if score, value, ok := decodeInsertPayload(rec.payload); ok {
+			_ = mem.Delete(score, value)
+		}
+	case opDeleteRangeByScore:
+		if min, max, ok := decodeRangeByScorePayload(rec.payload); ok {
+			mem.DeleteRangeByScore(skiplist.Range[float64]{Min: min, Max: max})
+		}
+	case opDeleteRangeByRank:
+		if start, end, ok := decodeRangeByRankPayload(rec.payload); ok {
+			mem.DeleteRangeByRank(start, end)
+		}
+	}
+}
+
+// Insert 插入元素：先写 WAL，成功后再应用到内存表。
+func (db *DB) Insert(score float64, value string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.wal.Append(opInsert, encodeInsertPayload(score, value)); err != nil {
+		return err
+	}
+	db.mem.Insert(score, value)
+	return nil
+}
+
+// Delete 删除匹配的元素<score, value>。
+func (db *DB) Delete(score float64, value string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.wal.Append(opDelete, encodeInsertPayload(score, value)); err != nil {
+		return err
+	}
+	return db.mem.Delete(score, value)
+}
+
+// DeleteRangeByScore 删除给定 score 范围内的元素，返回删除数量。
+func (db *DB) DeleteRangeByScore(r skiplist.Range[float64]) (uint64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.wal.Append(opDeleteRangeByScore, encodeRangeByScorePayload(r.Min, r.Max)); err != nil {
+		return 0, err
+	}
+	return db.mem.DeleteRangeByScore(r), nil
+}
+
+// DeleteRangeByRank 删除给定排序范围内的所有元素，返回删除数量。
+func (db *DB) DeleteRangeByRank(start, end uint64) (uint64, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, err := db.wal.Append(opDeleteRangeByRank, encodeRangeByRankPayload(start, end)); err != nil {
+		return 0, err
+	}
+	return db.mem.DeleteRangeByRank(start, end), nil
+}
+
+// Checkpoint 将内存表刷成一个新的不可变 segment 文件，并清空 WAL。
+// seq 取当前 WAL 序列号，使 Open 能够判断该 segment 已经包含了哪些记录。
+func (db *DB) Checkpoint() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	entries := make([]segmentEntry, 0, db.mem.Len())
+	db.mem.Iterate(func(score float64, value string) bool {
+		entries = append(entries, segmentEntry{score: score, value: value, span: 1})
+		return true
+	})
+
+	seq := db.wal.seq
+	if err := writeSegment(db.dir, seq, entries); err != nil {
+		return err
+	}
+	db.segs = append(db.segs, seq)
+
+	return db.wal.reset()
+}
+
+// Close 停止后台 compactor 并关闭 WAL 文件。
+func (db *DB) Close() error {
+	close(db.closeC)
+	db.wg.Wait()
+	return db.wal.Close()
+}
+
+// compactLoop 周期性地清理除最新一个之外的全部 segment，
+// 以回收被覆盖/删除的旧版本数据占用的磁盘空间。
+func (db *DB) compactLoop() {
+	defer db.wg.Done()
+
+	ticker := time.NewTicker(db.compactInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.closeC:
+			return
+		case <-ticker.C:
+			_ = db.compactOnce()
+		}
+	}
+}
+
+// compactOnce 删除除最新一个之外的所有 segment 文件。
+//
+// 每次 Checkpoint 都会把当时内存表的*全部*内容写成一个新 segment，因此每个
+// segment 本身就是一份完整快照，而不是一份增量。按 (score, value) 做跨
+// segment 的并集合并是错误的：较旧 segment 里仍然保留着、但已经在较新
+// segment 对应快照中被删除的 key，合并会把它们重新带回来。既然最新 segment
+// 已经包含了全部有效数据，压缩只需要保留它、丢弃其余的即可。
+func (db *DB) compactOnce() error {
+	db.mu.Lock()
+	segs := append([]uint64(nil), db.segs...)
+	db.mu.Unlock()
+
+	if len(segs) < 2 {
+		return nil
+	}
+	newest := segs[len(segs)-1]
+	toRemove := segs[:len(segs)-1]
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	newSegs := []uint64{newest}
+	for _, seq := range db.segs {
+		if seq > newest {
+			newSegs = append(newSegs, seq)
+		}
+	}
+	db.segs = newSegs
+
+	for _, seq := range toRemove {
+		if seq == newest {
+			continue
+		}
+		_ = os.Remove(segmentPath(db.dir, seq))
+	}
+	return nil
+}
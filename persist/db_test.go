@@ -0,0 +1,90 @@
+package persist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_InsertCheckpointReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := Open(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Insert(3, "hello"))
+	require.NoError(t, db.Insert(9, "rust"))
+	require.NoError(t, db.Insert(9, "c++"))
+	require.NoError(t, db.Checkpoint())
+	require.NoError(t, db.Insert(10, "golang"))
+	require.NoError(t, db.Close())
+
+	db2, err := Open(dir)
+	require.NoError(t, err)
+	defer db2.Close()
+
+	require.Equal(t, uint64(4), db2.mem.Len())
+
+	rank, err := db2.mem.GetRank(10, "golang")
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), rank)
+}
+
+func TestDB_CompactOnceDropsStaleSegmentsWithoutResurrectingDeletes(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := Open(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Insert(1, "a"))
+	require.NoError(t, db.Insert(2, "b"))
+	require.NoError(t, db.Checkpoint()) // segment #1: {a, b}
+
+	require.NoError(t, db.Delete(2, "b"))
+	require.NoError(t, db.Insert(3, "c"))
+	require.NoError(t, db.Checkpoint()) // segment #2 (newest): {a, c}
+
+	require.Len(t, db.segs, 2)
+
+	require.NoError(t, db.compactOnce())
+	require.Len(t, db.segs, 1)
+
+	// 旧 segment 中的 b 早已在新 segment 对应的快照里被删除，
+	// 简单的跨 segment 并集合并会把它错误地带回来；压缩必须只保留最新快照。
+	segs, err := listSegments(dir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{db.segs[0]}, segs)
+
+	data, unmap, err := mmapSegment(segmentPath(dir, db.segs[0]))
+	require.NoError(t, err)
+	defer unmap()
+	entries, _, err := parseSegment(data)
+	require.NoError(t, err)
+
+	var values []string
+	for _, e := range entries {
+		values = append(values, e.value)
+	}
+	require.ElementsMatch(t, []string{"a", "c"}, values)
+}
+
+func TestDB_DeleteAndRangeOps(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := Open(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Insert(1, "a"))
+	require.NoError(t, db.Insert(2, "b"))
+	require.NoError(t, db.Insert(3, "c"))
+
+	require.NoError(t, db.Delete(2, "b"))
+
+	removed, err := db.DeleteRangeByRank(1, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), removed)
+
+	require.Equal(t, uint64(1), db.mem.Len())
+}
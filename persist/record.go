@@ -0,0 +1,123 @@
+package persist
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+// 操作类型，记录在每条 WAL record 的第一个字节
+type opType byte
+
+const (
+	opInsert opType = iota + 1
+	opDelete
+	opDeleteRangeByScore
+	opDeleteRangeByRank
+)
+
+// record 是写入 WAL 的一条日志：seq 单调递增，用于 Open 时判断重放起点，
+// payload 是 op 相关的编码数据。record 在磁盘上的布局为：
+//
+//	[length uint32][crc32 uint32][seq uint64][op byte][payload ...]
+//
+// length 覆盖 crc32 之后的全部字节；crc32 覆盖 seq/op/payload。
+type record struct {
+	seq     uint64
+	op      opType
+	payload []byte
+}
+
+func encodeRecord(r record) []byte {
+	body := make([]byte, 8+1+len(r.payload))
+	binary.BigEndian.PutUint64(body[0:8], r.seq)
+	body[8] = byte(r.op)
+	copy(body[9:], r.payload)
+
+	buf := make([]byte, 4+4+len(body))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(body)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(body))
+	copy(buf[8:], body)
+	return buf
+}
+
+// readRecord 从 r 读取一条记录。读到 io.EOF 表示文件正常结束；
+// 校验和不匹配时返回 errCorruptRecord，调用方应在该位置截断 WAL。
+func readRecord(r io.Reader) (record, error) {
+	var lenBuf, crcBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return record{}, err
+	}
+	if _, err := io.ReadFull(r, crcBuf[:]); err != nil {
+		return record{}, errCorruptRecord
+	}
+
+	length := binary.BigEndian.Uint32(lenBuf[:])
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return record{}, errCorruptRecord
+	}
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(crcBuf[:]) {
+		return record{}, errCorruptRecord
+	}
+	if len(body) < 9 {
+		return record{}, errCorruptRecord
+	}
+
+	return record{
+		seq:     binary.BigEndian.Uint64(body[0:8]),
+		op:      opType(body[8]),
+		payload: body[9:],
+	}, nil
+}
+
+func encodeInsertPayload(score float64, value string) []byte {
+	buf := make([]byte, 8+4+len(value))
+	binary.BigEndian.PutUint64(buf[0:8], floatBits(score))
+	binary.BigEndian.PutUint32(buf[8:12], uint32(len(value)))
+	copy(buf[12:], value)
+	return buf
+}
+
+func decodeInsertPayload(p []byte) (score float64, value string, ok bool) {
+	if len(p) < 12 {
+		return 0, "", false
+	}
+	score = floatFromBits(binary.BigEndian.Uint64(p[0:8]))
+	n := binary.BigEndian.Uint32(p[8:12])
+	if uint32(len(p)-12) < n {
+		return 0, "", false
+	}
+	return score, string(p[12 : 12+n]), true
+}
+
+func encodeRangeByScorePayload(min, max float64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[0:8], floatBits(min))
+	binary.BigEndian.PutUint64(buf[8:16], floatBits(max))
+	return buf
+}
+
+func decodeRangeByScorePayload(p []byte) (min, max float64, ok bool) {
+	if len(p) < 16 {
+		return 0, 0, false
+	}
+	min = floatFromBits(binary.BigEndian.Uint64(p[0:8]))
+	max = floatFromBits(binary.BigEndian.Uint64(p[8:16]))
+	return min, max, true
+}
+
+func encodeRangeByRankPayload(start, end uint64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[0:8], start)
+	binary.BigEndian.PutUint64(buf[8:16], end)
+	return buf
+}
+
+func decodeRangeByRankPayload(p []byte) (start, end uint64, ok bool) {
+	if len(p) < 16 {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint64(p[0:8]), binary.BigEndian.Uint64(p[8:16]), true
+}
@@ -0,0 +1,155 @@
+package persist
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	segmentMagic   = 0x534B4950 // "SKIP"
+	indexInterval  = 128 // 每隔多少条记录写入一个稀疏索引项
+	trailerSize    = 8 + 4 + 8 + 4
+)
+
+// segmentEntry 是落盘到 segment 文件中的一条数据：score/value 与插入时的 Span，
+// Span 取自内存 SkipList 对应节点在第 0 层的跨度，供将来做 rank 相关的快速恢复。
+type segmentEntry struct {
+	score float64
+	value string
+	span  uint64
+}
+
+// sparseIndexEntry 记录每隔 indexInterval 条 entry 的文件偏移与 score，
+// 用于在不整表扫描的情况下粗定位目标 score 所在的区间。
+type sparseIndexEntry struct {
+	offset uint64
+	score  float64
+}
+
+func segmentPath(dir string, seq uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("segment-%020d.seg", seq))
+}
+
+// writeSegment 将已按 (score, value) 排序的 entries 写成一个不可变的 segment 文件。
+func writeSegment(dir string, seq uint64, entries []segmentEntry) error {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].score != entries[j].score {
+			return entries[i].score < entries[j].score
+		}
+		return entries[i].value < entries[j].value
+	})
+
+	f, err := os.OpenFile(segmentPath(dir, seq), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var index []sparseIndexEntry
+	offset := uint64(0)
+	for i, e := range entries {
+		if i%indexInterval == 0 {
+			index = append(index, sparseIndexEntry{offset: offset, score: e.score})
+		}
+
+		buf := make([]byte, 8+4+len(e.value)+8)
+		binary.BigEndian.PutUint64(buf[0:8], floatBits(e.score))
+		binary.BigEndian.PutUint32(buf[8:12], uint32(len(e.value)))
+		copy(buf[12:12+len(e.value)], e.value)
+		binary.BigEndian.PutUint64(buf[12+len(e.value):], e.span)
+
+		if _, err := f.Write(buf); err != nil {
+			return err
+		}
+		offset += uint64(len(buf))
+	}
+
+	indexOffset := offset
+	for _, ix := range index {
+		buf := make([]byte, 16)
+		binary.BigEndian.PutUint64(buf[0:8], ix.offset)
+		binary.BigEndian.PutUint64(buf[8:16], floatBits(ix.score))
+		if _, err := f.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	trailer := make([]byte, trailerSize)
+	binary.BigEndian.PutUint64(trailer[0:8], indexOffset)
+	binary.BigEndian.PutUint32(trailer[8:12], uint32(len(index)))
+	binary.BigEndian.PutUint64(trailer[12:20], uint64(len(entries)))
+	binary.BigEndian.PutUint32(trailer[20:24], segmentMagic)
+	if _, err := f.Write(trailer); err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// parseSegment 解析已经整体读入内存（无论来自 mmap 还是普通读取）的 segment 数据，
+// 返回其中按顺序排列的全部 entry 以及稀疏索引。
+func parseSegment(data []byte) ([]segmentEntry, []sparseIndexEntry, error) {
+	if len(data) < trailerSize {
+		return nil, nil, fmt.Errorf("persist: segment too small")
+	}
+
+	trailer := data[len(data)-trailerSize:]
+	magic := binary.BigEndian.Uint32(trailer[20:24])
+	if magic != segmentMagic {
+		return nil, nil, fmt.Errorf("persist: bad segment magic")
+	}
+	indexOffset := binary.BigEndian.Uint64(trailer[0:8])
+	indexCount := binary.BigEndian.Uint32(trailer[8:12])
+	entryCount := binary.BigEndian.Uint64(trailer[12:20])
+
+	entries := make([]segmentEntry, 0, entryCount)
+	offset := uint64(0)
+	for uint64(len(entries)) < entryCount {
+		if offset+12 > indexOffset {
+			return nil, nil, fmt.Errorf("persist: truncated segment entry")
+		}
+		score := floatFromBits(binary.BigEndian.Uint64(data[offset : offset+8]))
+		valueLen := binary.BigEndian.Uint32(data[offset+8 : offset+12])
+		start := offset + 12
+		end := start + uint64(valueLen)
+		if end+8 > indexOffset {
+			return nil, nil, fmt.Errorf("persist: truncated segment entry")
+		}
+		value := string(data[start:end])
+		span := binary.BigEndian.Uint64(data[end : end+8])
+		entries = append(entries, segmentEntry{score: score, value: value, span: span})
+		offset = end + 8
+	}
+
+	index := make([]sparseIndexEntry, 0, indexCount)
+	ixOff := indexOffset
+	for i := uint32(0); i < indexCount; i++ {
+		off := binary.BigEndian.Uint64(data[ixOff : ixOff+8])
+		score := floatFromBits(binary.BigEndian.Uint64(data[ixOff+8 : ixOff+16]))
+		index = append(index, sparseIndexEntry{offset: off, score: score})
+		ixOff += 16
+	}
+
+	return entries, index, nil
+}
+
+// listSegments 按序列号从小到大列出 dir 下的所有 segment 文件。
+func listSegments(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs []uint64
+	for _, e := range entries {
+		var seq uint64
+		if _, err := fmt.Sscanf(e.Name(), "segment-%d.seg", &seq); err == nil {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package persist
+
+import "os"
+
+// mmapSegment 在不支持 syscall.Mmap 的平台上退化为整文件读取，接口与
+// unix 版本保持一致，调用方无需关心底层是否真正做了内存映射。
+func mmapSegment(path string) (data []byte, unmap func() error, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package persist
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapSegment 通过 mmap 将 segment 文件映射到内存中只读，避免整文件拷贝。
+// 调用方负责在用完后调用返回的 unmap 函数。
+func mmapSegment(path string) (data []byte, unmap func() error, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	data, err = syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,11 @@
+package persist
+
+import (
+	"errors"
+	"math"
+)
+
+var errCorruptRecord = errors.New("persist: corrupt record")
+
+func floatBits(f float64) uint64    { return math.Float64bits(f) }
+func floatFromBits(b uint64) float64 { return math.Float64frombits(b) }
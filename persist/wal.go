@@ -0,0 +1,88 @@
+package persist
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const walFileName = "wal.log"
+
+// wal 是追加写入的预写日志文件，每次 Insert/Delete/DeleteRangeByScore/
+// DeleteRangeByRank 都会先落盘到这里，再应用到内存中的 SkipList。
+type wal struct {
+	f   *os.File
+	seq uint64
+}
+
+func openWAL(dir string) (*wal, error) {
+	f, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &wal{f: f}, nil
+}
+
+// Append 写入一条记录并 fsync，返回写入时使用的序列号。
+func (w *wal) Append(op opType, payload []byte) (uint64, error) {
+	w.seq++
+	buf := encodeRecord(record{seq: w.seq, op: op, payload: payload})
+	if _, err := w.f.Write(buf); err != nil {
+		w.seq--
+		return 0, err
+	}
+	if err := w.f.Sync(); err != nil {
+		return 0, err
+	}
+	return w.seq, nil
+}
+
+// replay 读取自 afterSeq 之后的全部记录（不含 afterSeq 本身），并通过 apply 回调逐条应用。
+// 遇到第一个 CRC 校验失败的记录时，将 WAL 截断到该位置，视为正常的"未完成写入"结尾。
+func (w *wal) replay(afterSeq uint64, apply func(record) error) error {
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(w.f)
+
+	offset := int64(0)
+	for {
+		rec, err := readRecord(r)
+		if err == errCorruptRecord {
+			return w.f.Truncate(offset)
+		}
+		if err != nil {
+			break // io.EOF：正常结束
+		}
+		offset += int64(8 + 9 + len(rec.payload))
+
+		if rec.seq > w.seq {
+			w.seq = rec.seq
+		}
+		if rec.seq <= afterSeq {
+			continue
+		}
+		if err := apply(rec); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.f.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reset 清空 WAL 内容，在 Checkpoint 将内存数据落盘为新 segment 之后调用。
+func (w *wal) reset() error {
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.f.Seek(0, io.SeekStart)
+	return err
+}
+
+func (w *wal) Close() error {
+	return w.f.Close()
+}
@@ -3,70 +3,93 @@ package skiplist
 import (
 	"errors"
 	"math/rand"
-	"time"
 )
 
 var (
 	ErrNotFound = errors.New("not found")
 )
 
-const MaxLevel = 64
+// DefaultMaxLevel 是未通过 WithMaxLevel 指定层数上限时使用的默认值。
+const DefaultMaxLevel = 64
 
-type SkipList struct {
-	Header *SkipNode
-	Tail   *SkipNode // 跳跃表尾节点
-	Level  int       // 最大的层数
-	Len    uint64    // 节点数量
+// DefaultProbability 是未通过 WithProbability 指定分层概率时使用的默认值。
+const DefaultProbability = 0.25
+
+// CompareFunc 比较 a 与 b 的大小关系：返回负数表示 a<b，0 表示 a==b，正数表示 a>b。
+// 跳跃表按照该函数给出的顺序维护节点。
+type CompareFunc[K any] func(a, b K) int
+
+type SkipList[K any, V any] struct {
+	Header  *SkipNode[K, V]
+	Tail    *SkipNode[K, V] // 跳跃表尾节点
+	Level   int             // 最大的层数
+	Len     uint64          // 节点数量
+	compare CompareFunc[K]
+
+	maxLevel    int
+	probability float64
+	rnd         *rand.Rand
 }
 
-type SkipLevel struct {
-	Forward *SkipNode
+type SkipLevel[K any, V any] struct {
+	Forward *SkipNode[K, V]
 	Span    uint64 // 与 Forward 节点的跨度（距离）
 }
 
-type SkipNode struct {
-	Level    []SkipLevel
-	Backward *SkipNode
-	Score    float64
-	Value    string
+type SkipNode[K any, V any] struct {
+	Level    []SkipLevel[K, V]
+	Backward *SkipNode[K, V]
+	Key      K
+	Value    V
 }
 
-func createNode(level int, score float64, value string) *SkipNode {
-	return &SkipNode{
-		Level:    make([]SkipLevel, level),
+func createNode[K any, V any](level int, key K, value V) *SkipNode[K, V] {
+	return &SkipNode[K, V]{
+		Level:    make([]SkipLevel[K, V], level),
 		Backward: nil,
-		Score:    score,
+		Key:      key,
 		Value:    value,
 	}
 }
 
 // Range 范围，左右闭区间[Min, Max]
-type Range struct {
-	Min float64
-	Max float64
+type Range[K any] struct {
+	Min K
+	Max K
 }
 
-func (r *Range) GteMin(score float64) bool {
-	return score >= r.Min
-}
-
-func (r *Range) LetMax(score float64) bool {
-	return score <= r.Max
-}
+// New 创建一个以 compare 为排序依据的空跳跃表，opts 可用于调整分层概率、
+// 层数上限以及随机数来源，参见 WithProbability/WithMaxLevel/WithRandSource。
+func New[K any, V any](compare CompareFunc[K], opts ...Option) *SkipList[K, V] {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-func New() *SkipList {
-	return &SkipList{
-		Level:  1,
-		Len:    0,
-		Header: createNode(MaxLevel, 0, ""),
-		Tail:   nil,
+	var zeroK K
+	var zeroV V
+	return &SkipList[K, V]{
+		Level:       1,
+		Len:         0,
+		Header:      createNode[K, V](o.maxLevel, zeroK, zeroV),
+		Tail:        nil,
+		compare:     compare,
+		maxLevel:    o.maxLevel,
+		probability: o.probability,
+		rnd:         rand.New(o.source),
 	}
 }
 
 // Insert 插入元素，注意：该方法内没做元素唯一性检测
-func (sl *SkipList) Insert(score float64, value string) {
-	update := make([]*SkipNode, MaxLevel) // 存储各层的前置节点
-	rank := make([]uint64, MaxLevel)      // 存储各层前置节点的排名
+func (sl *SkipList[K, V]) Insert(key K, value V) {
+	sl.insertNode(key, value)
+}
+
+// insertNode 插入元素并返回新创建的节点，供需要持有节点引用的上层结构
+// （如 ZSet 的 UniqueByValue 模式）使用。
+func (sl *SkipList[K, V]) insertNode(key K, value V) *SkipNode[K, V] {
+	update := make([]*SkipNode[K, V], sl.maxLevel) // 存储各层的前置节点
+	rank := make([]uint64, sl.maxLevel)             // 存储各层前置节点的排名
 
 	// 找到前一个节点的位置及排名
 	x := sl.Header
@@ -74,8 +97,7 @@ func (sl *SkipList) Insert(score float64, value string) {
 		if i != sl.Level-1 {
 			rank[i] = rank[i+1]
 		}
-		for x.Level[i].Forward != nil && (x.Level[i].Forward.Score < score ||
-			(x.Level[i].Forward.Score == score && x.Level[i].Forward.Value < value)) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, key) < 0 {
 			rank[i] += x.Level[i].Span
 			x = x.Level[i].Forward
 		}
@@ -94,7 +116,7 @@ func (sl *SkipList) Insert(score float64, value string) {
 	}
 
 	// 插入节点并更新forward与backward
-	x = createNode(level, score, value)
+	x = createNode(level, key, value)
 	for i := 0; i < level; i++ {
 		x.Level[i].Forward = update[i].Level[i].Forward // 设置x的i层后置索引
 		update[i].Level[i].Forward = x                  // 设置前置节点第i层的后置索引
@@ -118,23 +140,23 @@ func (sl *SkipList) Insert(score float64, value string) {
 	}
 
 	sl.Len++
+	return x
 }
 
-// Delete 删除匹配的元素<score, value>
-func (sl *SkipList) Delete(score float64, value string) error {
-	update := make([]*SkipNode, MaxLevel)
+// Delete 删除匹配 key 的元素
+func (sl *SkipList[K, V]) Delete(key K) error {
+	update := make([]*SkipNode[K, V], sl.maxLevel)
 
 	x := sl.Header
 	for i := sl.Level - 1; i >= 0; i-- {
-		for x.Level[i].Forward != nil && (x.Level[i].Forward.Score < score ||
-			(x.Level[i].Forward.Score == score && x.Level[i].Forward.Value < value)) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, key) < 0 {
 			x = x.Level[i].Forward
 		}
 		update[i] = x
 	}
 
 	x = x.Level[0].Forward
-	if x != nil && x.Score == score && x.Value == value {
+	if x != nil && sl.compare(x.Key, key) == 0 {
 		sl.DeleteNode(x, update)
 		return nil
 	}
@@ -143,7 +165,7 @@ func (sl *SkipList) Delete(score float64, value string) error {
 }
 
 // DeleteNode 删除给定的节点
-func (sl *SkipList) DeleteNode(x *SkipNode, update []*SkipNode) {
+func (sl *SkipList[K, V]) DeleteNode(x *SkipNode[K, V], update []*SkipNode[K, V]) {
 	for i := 0; i < sl.Level; i++ {
 		if update[i].Level[i].Forward == x {
 			update[i].Level[i].Span += x.Level[i].Span - 1
@@ -167,16 +189,15 @@ func (sl *SkipList) DeleteNode(x *SkipNode, update []*SkipNode) {
 }
 
 // GetRank 返回目标元素在有序集中的 rank
-func (sl *SkipList) GetRank(score float64, value string) (uint64, error) {
+func (sl *SkipList[K, V]) GetRank(key K) (uint64, error) {
 	rank := uint64(0)
 	x := sl.Header
 	for i := sl.Level - 1; i >= 0; i-- {
-		for x.Level[i].Forward != nil && (x.Level[i].Forward.Score < score ||
-			(x.Level[i].Forward.Score == score && x.Level[i].Forward.Value <= value)) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, key) <= 0 {
 			rank += x.Level[i].Span
 			x = x.Level[i].Forward
 		}
-		if x.Value == value {
+		if x != sl.Header && sl.compare(x.Key, key) == 0 {
 			return rank, nil
 		}
 	}
@@ -184,7 +205,7 @@ func (sl *SkipList) GetRank(score float64, value string) (uint64, error) {
 }
 
 // GetValueByRank 根据给定的 rank 查找元素
-func (sl *SkipList) GetValueByRank(rank uint64) (string, error) {
+func (sl *SkipList[K, V]) GetValueByRank(rank uint64) (V, error) {
 	x := sl.Header
 	traversed := uint64(0)
 	for i := sl.Level - 1; i >= 0; i-- {
@@ -196,24 +217,23 @@ func (sl *SkipList) GetValueByRank(rank uint64) (string, error) {
 			return x.Value, nil
 		}
 	}
-	return "", ErrNotFound
+	var zero V
+	return zero, ErrNotFound
 }
 
 // IsInRange 检查在给定范围内是否存在元素
-func (sl *SkipList) IsInRange(r Range) bool {
-	if r.Min > r.Max {
+func (sl *SkipList[K, V]) IsInRange(r Range[K]) bool {
+	if sl.compare(r.Min, r.Max) > 0 {
 		return false
 	}
 
 	x := sl.Tail
-	if x == nil || !r.GteMin(x.Score) {
-		// x == nil || x.score < min
+	if x == nil || sl.compare(x.Key, r.Min) < 0 {
 		return false
 	}
 
 	x = sl.Header.Level[0].Forward
-	if x == nil || !r.LetMax(x.Score) {
-		// x == nil || x.score > max
+	if x == nil || sl.compare(x.Key, r.Max) > 0 {
 		return false
 	}
 
@@ -221,21 +241,21 @@ func (sl *SkipList) IsInRange(r Range) bool {
 }
 
 // FirstInRange 找到跳跃表中第一个符合给定范围的元素
-func (sl *SkipList) FirstInRange(r Range) (*SkipNode, error) {
+func (sl *SkipList[K, V]) FirstInRange(r Range[K]) (*SkipNode[K, V], error) {
 	if !sl.IsInRange(r) {
 		return nil, ErrNotFound
 	}
 
-	// 找到第一个 Score 值小于给定范围最小值的节点
+	// 找到最后一个 Key 值小于给定范围最小值的节点
 	x := sl.Header
 	for i := sl.Level - 1; i >= 0; i-- {
-		for x.Level[i].Forward != nil && !r.GteMin(x.Level[i].Forward.Score) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, r.Min) < 0 {
 			x = x.Level[i].Forward
 		}
 	}
 
 	x = x.Level[0].Forward
-	if x == nil || !r.LetMax(x.Score) {
+	if x == nil || sl.compare(x.Key, r.Max) > 0 {
 		return nil, ErrNotFound
 	}
 
@@ -243,33 +263,33 @@ func (sl *SkipList) FirstInRange(r Range) (*SkipNode, error) {
 }
 
 // LastInRange 找到跳跃表中最后一个符合给定范围的元素
-func (sl *SkipList) LastInRange(r Range) (*SkipNode, error) {
+func (sl *SkipList[K, V]) LastInRange(r Range[K]) (*SkipNode[K, V], error) {
 	if !sl.IsInRange(r) {
 		return nil, ErrNotFound
 	}
 
 	x := sl.Header
 	for i := sl.Level - 1; i >= 0; i-- {
-		for x.Level[i].Forward != nil && r.LetMax(x.Level[i].Forward.Score) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, r.Max) <= 0 {
 			x = x.Level[i].Forward
 		}
 	}
 
-	if !r.GteMin(x.Score) {
+	if sl.compare(x.Key, r.Min) < 0 {
 		return nil, ErrNotFound
 	}
 
 	return x, nil
 }
 
-// DeleteRangeByScore 删除给定范围内的 score 的元素
-func (sl *SkipList) DeleteRangeByScore(r Range) uint64 {
-	update := make([]*SkipNode, MaxLevel)
+// DeleteRangeByKey 删除给定范围内的所有元素
+func (sl *SkipList[K, V]) DeleteRangeByKey(r Range[K]) uint64 {
+	update := make([]*SkipNode[K, V], sl.maxLevel)
 	removed := uint64(0)
 
 	x := sl.Header
 	for i := sl.Level - 1; i >= 0; i-- {
-		for x.Level[i].Forward != nil && !r.GteMin(x.Level[i].Forward.Score) {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, r.Min) < 0 {
 			x = x.Level[i].Forward
 		}
 		update[i] = x
@@ -278,7 +298,7 @@ func (sl *SkipList) DeleteRangeByScore(r Range) uint64 {
 	// 待删除的第一个节点
 	x = x.Level[0].Forward
 
-	for x != nil && r.LetMax(x.Score) {
+	for x != nil && sl.compare(x.Key, r.Max) <= 0 {
 		// 后继指针
 		next := x.Level[0].Forward
 		// 删除
@@ -291,8 +311,8 @@ func (sl *SkipList) DeleteRangeByScore(r Range) uint64 {
 }
 
 // DeleteRangeByRank 删除给定排序范围内的所有元素
-func (sl *SkipList) DeleteRangeByRank(start, end uint64) uint64 {
-	update := make([]*SkipNode, MaxLevel)
+func (sl *SkipList[K, V]) DeleteRangeByRank(start, end uint64) uint64 {
+	update := make([]*SkipNode[K, V], sl.maxLevel)
 	traversed, removed := uint64(0), uint64(0)
 
 	x := sl.Header
@@ -318,18 +338,14 @@ func (sl *SkipList) DeleteRangeByRank(start, end uint64) uint64 {
 	return removed
 }
 
-var rd *rand.Rand
-
-func init() {
-	rd = rand.New(rand.NewSource(time.Now().UnixNano()))
-}
-func (sl *SkipList) randomLevel() int {
+// randomLevel 依据 sl.probability 抛硬币式地决定新节点的层数，上限为 sl.maxLevel。
+func (sl *SkipList[K, V]) randomLevel() int {
 	level := 1
-	for rd.Intn(100) < 25 { // 默认25%的几率
+	for sl.rnd.Float64() < sl.probability {
 		level++
 	}
-	if level > MaxLevel {
-		return MaxLevel
+	if level > sl.maxLevel {
+		return sl.maxLevel
 	}
 	return level
 }
@@ -0,0 +1,83 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func compareInt(a, b int) int {
+	return a - b
+}
+
+func compareString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func TestSkipList_Generic(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		sl := New[int, string](compareInt)
+		sl.Insert(3, "three")
+		sl.Insert(1, "one")
+		sl.Insert(2, "two")
+
+		rank, err := sl.GetRank(2)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), rank)
+
+		value, err := sl.GetValueByRank(1)
+		require.NoError(t, err)
+		require.Equal(t, "one", value)
+
+		require.NoError(t, sl.Delete(2))
+		_, err = sl.GetRank(2)
+		require.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		sl := New[string, int](compareString)
+		sl.Insert("banana", 1)
+		sl.Insert("apple", 2)
+		sl.Insert("cherry", 3)
+
+		value, err := sl.GetValueByRank(1)
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+
+		node, err := sl.FirstInRange(Range[string]{Min: "banana", Max: "cherry"})
+		require.NoError(t, err)
+		require.Equal(t, "banana", node.Key)
+	})
+
+	t.Run("composite structs", func(t *testing.T) {
+		type point struct {
+			X, Y int
+		}
+		compare := func(a, b point) int {
+			if a.X != b.X {
+				return a.X - b.X
+			}
+			return a.Y - b.Y
+		}
+
+		sl := New[point, string](compare)
+		sl.Insert(point{1, 2}, "a")
+		sl.Insert(point{1, 1}, "b")
+		sl.Insert(point{0, 5}, "c")
+
+		value, err := sl.GetValueByRank(1)
+		require.NoError(t, err)
+		require.Equal(t, "c", value)
+
+		rank, err := sl.GetRank(point{1, 2})
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), rank)
+	})
+}
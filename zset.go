@@ -0,0 +1,349 @@
+package skiplist
+
+import "errors"
+
+// ErrUniqueModeRequired 在非 UniqueByValue 模式下调用依赖 value 唯一性索引
+// 的方法（如 IncrBy）时返回。
+var ErrUniqueModeRequired = errors.New("skiplist: requires UniqueByValue mode")
+
+// zsetKey 是 ZSet 的排序键：先按 Score 排序，Score 相等时按 Value 排序，
+// 与旧版 SkipList 的 (Score float64, Value string) 排序规则保持一致。
+type zsetKey struct {
+	Score float64
+	Value string
+}
+
+func compareZSetKey(a, b zsetKey) int {
+	if a.Score != b.Score {
+		if a.Score < b.Score {
+			return -1
+		}
+		return 1
+	}
+	if a.Value != b.Value {
+		if a.Value < b.Value {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// ZSetNode 是 ZSet 遍历结果返回的只读节点视图。
+type ZSetNode struct {
+	Score float64
+	Value string
+}
+
+func zsetNode(n *SkipNode[zsetKey, string]) *ZSetNode {
+	if n == nil {
+		return nil
+	}
+	return &ZSetNode{Score: n.Key.Score, Value: n.Value}
+}
+
+// ZSet 是基于泛型 SkipList[K, V] 构建的 (Score float64, Value string) 有序集合，
+// 保留了旧版 SkipList 的 API，供历史调用方直接替换使用。
+type ZSet struct {
+	sl *SkipList[zsetKey, string]
+
+	unique  bool // 是否启用 UniqueByValue 模式
+	byValue map[string]*SkipNode[zsetKey, string]
+}
+
+// NewZSet 创建一个空的 ZSet，opts 的含义与 New 相同。重复的 <score, value>
+// 不做唯一性检测，如需按 value 去重的 upsert 语义请使用 NewUniqueZSet。
+func NewZSet(opts ...Option) *ZSet {
+	return &ZSet{sl: New[zsetKey, string](compareZSetKey, opts...)}
+}
+
+// NewUniqueZSet 创建一个启用 UniqueByValue 模式的 ZSet：Insert 对同一 value
+// 的重复调用是 upsert 语义——节点会被重新定位到新的 score，而不是新增一个节点，
+// 对应 Redis ZADD 的默认行为；IncrBy 对应 ZINCRBY。
+func NewUniqueZSet(opts ...Option) *ZSet {
+	z := NewZSet(opts...)
+	z.unique = true
+	z.byValue = make(map[string]*SkipNode[zsetKey, string])
+	return z
+}
+
+// Len 返回 ZSet 中的元素数量。
+func (z *ZSet) Len() uint64 {
+	return z.sl.Len
+}
+
+// Insert 插入元素。在 UniqueByValue 模式下这是 upsert 语义：若 value 已存在，
+// 对应节点会被重新定位到新的 score，而不是新增一个节点；非 UniqueByValue
+// 模式则不做唯一性检测，与旧版 SkipList.Insert 行为一致。
+func (z *ZSet) Insert(score float64, value string) {
+	if z.unique {
+		if node, ok := z.byValue[value]; ok {
+			if node.Key.Score != score {
+				z.relocate(node, score)
+			}
+			return
+		}
+		node := z.sl.insertNode(zsetKey{Score: score, Value: value}, value)
+		z.byValue[value] = node
+		return
+	}
+	z.sl.Insert(zsetKey{Score: score, Value: value}, value)
+}
+
+// Delete 删除匹配的元素<score, value>
+func (z *ZSet) Delete(score float64, value string) error {
+	err := z.sl.Delete(zsetKey{Score: score, Value: value})
+	if err == nil && z.unique {
+		delete(z.byValue, value)
+	}
+	return err
+}
+
+// Update 将已存在的元素 <oldScore, value> 移动到 newScore，时间复杂度 O(log n)。
+// 对于 UniqueByValue 模式下的 ZSet，这与直接调用 Insert(newScore, value)
+// 效果相同，但不要求调用方记住 value 原来的 score。
+func (z *ZSet) Update(oldScore float64, value string, newScore float64) error {
+	node, err := z.findNode(zsetKey{Score: oldScore, Value: value})
+	if err != nil {
+		return err
+	}
+	if oldScore != newScore {
+		z.relocate(node, newScore)
+	}
+	return nil
+}
+
+// IncrBy 将 value 对应元素的 score 增加 delta 并返回新的 score，
+// 对应 Redis 的 ZINCRBY。仅在 UniqueByValue 模式下可用，因为非唯一模式
+// 下同一个 value 可能对应多个节点，无法确定增量作用于哪一个。
+func (z *ZSet) IncrBy(value string, delta float64) (float64, error) {
+	if !z.unique {
+		return 0, ErrUniqueModeRequired
+	}
+	node, ok := z.byValue[value]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	newScore := node.Key.Score + delta
+	z.relocate(node, newScore)
+	return newScore, nil
+}
+
+// findNode 查找匹配 key 的节点，不做删除。
+func (z *ZSet) findNode(key zsetKey) (*SkipNode[zsetKey, string], error) {
+	sl := z.sl
+	x := sl.Header
+	for i := sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, key) < 0 {
+			x = x.Level[i].Forward
+		}
+	}
+	x = x.Level[0].Forward
+	if x != nil && sl.compare(x.Key, key) == 0 {
+		return x, nil
+	}
+	return nil, ErrNotFound
+}
+
+// relocate 将 node 从其当前位置摘除，并以新的 score 重新插入同一个节点对象，
+// 保持节点在整个操作过程中的身份不变（UniqueByValue 的 byValue 索引因此
+// 无需更新），同时维护各层 Span 计数的正确性。
+func (z *ZSet) relocate(node *SkipNode[zsetKey, string], newScore float64) {
+	sl := z.sl
+	oldKey := node.Key
+	newKey := zsetKey{Score: newScore, Value: node.Value}
+
+	// 1. 按旧 key 定位各层前置节点，将 node 从链表中摘除（不递减 Len）。
+	update := make([]*SkipNode[zsetKey, string], sl.maxLevel)
+	x := sl.Header
+	for i := sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, oldKey) < 0 {
+			x = x.Level[i].Forward
+		}
+		update[i] = x
+	}
+
+	for i := 0; i < sl.Level; i++ {
+		if update[i].Level[i].Forward == node {
+			update[i].Level[i].Span += node.Level[i].Span - 1
+			update[i].Level[i].Forward = node.Level[i].Forward
+		} else {
+			update[i].Level[i].Span--
+		}
+	}
+	if node.Level[0].Forward != nil {
+		node.Level[0].Forward.Backward = node.Backward
+	} else {
+		sl.Tail = node.Backward
+	}
+	for sl.Level > 1 && sl.Header.Level[sl.Level-1].Forward == nil {
+		sl.Level--
+	}
+
+	// 2. 按新 key 重新定位插入点，复用 node 原有的层数将其 splice 回链表。
+	level := len(node.Level)
+	update2 := make([]*SkipNode[zsetKey, string], sl.maxLevel)
+	rank := make([]uint64, sl.maxLevel)
+
+	x = sl.Header
+	for i := sl.Level - 1; i >= 0; i-- {
+		if i != sl.Level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.Level[i].Forward != nil && sl.compare(x.Level[i].Forward.Key, newKey) < 0 {
+			rank[i] += x.Level[i].Span
+			x = x.Level[i].Forward
+		}
+		update2[i] = x
+	}
+	if level > sl.Level {
+		for i := sl.Level; i < level; i++ {
+			rank[i] = 0
+			update2[i] = sl.Header
+			update2[i].Level[i].Span = sl.Len
+		}
+		sl.Level = level
+	}
+
+	node.Key = newKey
+	for i := 0; i < level; i++ {
+		node.Level[i].Forward = update2[i].Level[i].Forward
+		update2[i].Level[i].Forward = node
+		node.Level[i].Span = update2[i].Level[i].Span - (rank[0] - rank[i])
+		update2[i].Level[i].Span = (rank[0] - rank[i]) + 1
+	}
+	for i := level; i < sl.Level; i++ {
+		update2[i].Level[i].Span++
+	}
+
+	if update2[0] != sl.Header {
+		node.Backward = update2[0]
+	} else {
+		node.Backward = nil
+	}
+	if node.Level[0].Forward != nil {
+		node.Level[0].Forward.Backward = node
+	} else {
+		sl.Tail = node
+	}
+}
+
+// GetRank 返回目标元素在有序集中的 rank
+func (z *ZSet) GetRank(score float64, value string) (uint64, error) {
+	return z.sl.GetRank(zsetKey{Score: score, Value: value})
+}
+
+// GetValueByRank 根据给定的 rank 查找元素
+func (z *ZSet) GetValueByRank(rank uint64) (string, error) {
+	return z.sl.GetValueByRank(rank)
+}
+
+// IsInRange 检查在给定范围内是否存在元素，范围仅按 Score 比较
+func (z *ZSet) IsInRange(r Range[float64]) bool {
+	if r.Min > r.Max {
+		return false
+	}
+
+	tail := z.sl.Tail
+	if tail == nil || tail.Key.Score < r.Min {
+		return false
+	}
+
+	head := z.sl.Header.Level[0].Forward
+	if head == nil || head.Key.Score > r.Max {
+		return false
+	}
+
+	return true
+}
+
+// FirstInRange 找到 ZSet 中第一个 Score 落在给定范围内的元素
+func (z *ZSet) FirstInRange(r Range[float64]) (*ZSetNode, error) {
+	if !z.IsInRange(r) {
+		return nil, ErrNotFound
+	}
+
+	x := z.sl.Header
+	for i := z.sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && x.Level[i].Forward.Key.Score < r.Min {
+			x = x.Level[i].Forward
+		}
+	}
+
+	x = x.Level[0].Forward
+	if x == nil || x.Key.Score > r.Max {
+		return nil, ErrNotFound
+	}
+
+	return zsetNode(x), nil
+}
+
+// LastInRange 找到 ZSet 中最后一个 Score 落在给定范围内的元素
+func (z *ZSet) LastInRange(r Range[float64]) (*ZSetNode, error) {
+	if !z.IsInRange(r) {
+		return nil, ErrNotFound
+	}
+
+	x := z.sl.Header
+	for i := z.sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && x.Level[i].Forward.Key.Score <= r.Max {
+			x = x.Level[i].Forward
+		}
+	}
+
+	if x == z.sl.Header || x.Key.Score < r.Min {
+		return nil, ErrNotFound
+	}
+
+	return zsetNode(x), nil
+}
+
+// DeleteRangeByScore 删除给定范围内的 score 的元素
+func (z *ZSet) DeleteRangeByScore(r Range[float64]) uint64 {
+	update := make([]*SkipNode[zsetKey, string], z.sl.maxLevel)
+	removed := uint64(0)
+
+	x := z.sl.Header
+	for i := z.sl.Level - 1; i >= 0; i-- {
+		for x.Level[i].Forward != nil && x.Level[i].Forward.Key.Score < r.Min {
+			x = x.Level[i].Forward
+		}
+		update[i] = x
+	}
+
+	x = x.Level[0].Forward
+	for x != nil && x.Key.Score <= r.Max {
+		next := x.Level[0].Forward
+		if z.unique {
+			delete(z.byValue, x.Value)
+		}
+		z.sl.DeleteNode(x, update)
+		removed++
+		x = next
+	}
+
+	return removed
+}
+
+// DeleteRangeByRank 删除给定排序范围内的所有元素
+func (z *ZSet) DeleteRangeByRank(start, end uint64) uint64 {
+	if z.unique {
+		for r := start; r <= end; r++ {
+			value, err := z.sl.GetValueByRank(r)
+			if err != nil {
+				break
+			}
+			delete(z.byValue, value)
+		}
+	}
+	return z.sl.DeleteRangeByRank(start, end)
+}
+
+// Iterate 按 rank 升序遍历 ZSet 中的全部元素，fn 返回 false 时提前终止遍历。
+func (z *ZSet) Iterate(fn func(score float64, value string) bool) {
+	for x := z.sl.Header.Level[0].Forward; x != nil; x = x.Level[0].Forward {
+		if !fn(x.Key.Score, x.Value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZSet_UniqueByValue_Upsert(t *testing.T) {
+	z := NewUniqueZSet()
+
+	z.Insert(1, "alice")
+	z.Insert(2, "bob")
+	require.Equal(t, uint64(2), z.Len())
+
+	// 对同一 value 重复 Insert 是 upsert：节点被移动而不是新增。
+	z.Insert(5, "alice")
+	require.Equal(t, uint64(2), z.Len())
+
+	rank, err := z.GetRank(5, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), rank)
+
+	value, err := z.GetValueByRank(2)
+	require.NoError(t, err)
+	require.Equal(t, "alice", value)
+}
+
+func TestZSet_UniqueByValue_UpdateAndIncrBy(t *testing.T) {
+	z := NewUniqueZSet()
+	z.Insert(1, "alice")
+	z.Insert(2, "bob")
+	z.Insert(3, "carol")
+
+	require.NoError(t, z.Update(1, "alice", 10))
+	rank, err := z.GetRank(10, "alice")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), rank)
+
+	newScore, err := z.IncrBy("bob", 100)
+	require.NoError(t, err)
+	require.Equal(t, float64(102), newScore)
+
+	rank, err = z.GetRank(102, "bob")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), rank)
+
+	_, err = z.IncrBy("dave", 1)
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestZSet_NonUnique_IncrByRejected(t *testing.T) {
+	z := NewZSet()
+	_, err := z.IncrBy("anything", 1)
+	require.Equal(t, ErrUniqueModeRequired, err)
+}
+
+func TestZSet_UniqueByValue_DeleteCleansIndex(t *testing.T) {
+	z := NewUniqueZSet()
+	z.Insert(1, "a")
+	z.Insert(2, "b")
+	z.Insert(3, "c")
+
+	require.NoError(t, z.Delete(2, "b"))
+	z.Insert(9, "b") // 删除后重新插入应当是一次全新插入，而不是 upsert 到旧节点
+	require.Equal(t, uint64(3), z.Len())
+
+	rank, err := z.GetRank(9, "b")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), rank)
+
+	removed := z.DeleteRangeByRank(1, 2)
+	require.Equal(t, uint64(2), removed)
+	require.Equal(t, uint64(1), z.Len())
+}